@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// encoder writes a slice of records to an underlying writer in some
+// structured format. v is always a slice of record structs produced
+// by one of the *Records functions in ls.go.
+type encoder interface {
+	Encode(v interface{}) error
+}
+
+// newEncoder returns the encoder for the named format, writing to w.
+// format is one of "json", "csv", or "tsv".
+//
+// yaml is not supported yet: it needs a third-party package
+// (gopkg.in/yaml.v2) and this repo has no dependency-management
+// story to pull one in. Add it here once that exists.
+func newEncoder(format string, w io.Writer) (encoder, error) {
+	switch format {
+	case "json":
+		return jsonEncoder{json.NewEncoder(w)}, nil
+	case "csv":
+		return &delimEncoder{w: csv.NewWriter(w)}, nil
+	case "tsv":
+		cw := csv.NewWriter(w)
+		cw.Comma = '\t'
+		return &delimEncoder{w: cw}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q", format)
+}
+
+type jsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e jsonEncoder) Encode(v interface{}) error {
+	return e.enc.Encode(v)
+}
+
+// delimEncoder writes records as delimited rows, using the fields
+// of the first record (in struct order) as the header.
+type delimEncoder struct {
+	w *csv.Writer
+}
+
+func (e *delimEncoder) Encode(v interface{}) error {
+	s := reflect.ValueOf(v)
+	if s.Kind() != reflect.Slice {
+		return fmt.Errorf("csv/tsv output only supports lists, got %T", v)
+	}
+	if s.Len() == 0 {
+		return nil
+	}
+	t := s.Index(0).Type()
+	header := make([]string, t.NumField())
+	for i := range header {
+		header[i] = jsonTagName(t.Field(i).Tag.Get("json"))
+	}
+	if err := e.w.Write(header); err != nil {
+		return err
+	}
+	for i := 0; i < s.Len(); i++ {
+		rv := s.Index(i)
+		row := make([]string, rv.NumField())
+		for j := range row {
+			row[j] = fmt.Sprint(rv.Field(j).Interface())
+		}
+		if err := e.w.Write(row); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// jsonTagName extracts the field name from a struct's `json:"..."`
+// tag, dropping options like ",omitempty".
+func jsonTagName(tag string) string {
+	if i := strings.IndexByte(tag, ','); i >= 0 {
+		return tag[:i]
+	}
+	return tag
+}
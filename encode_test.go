@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestJSONTagName(t *testing.T) {
+	cases := map[string]string{
+		"app,omitempty": "app",
+		"app":           "app",
+		"":              "",
+	}
+	for tag, want := range cases {
+		if got := jsonTagName(tag); got != want {
+			t.Errorf("jsonTagName(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
+func TestDelimEncoderHeaderStripsOmitempty(t *testing.T) {
+	type row struct {
+		Name string `json:"name"`
+		App  string `json:"app,omitempty"`
+	}
+	var buf bytes.Buffer
+	enc, err := newEncoder("csv", &buf)
+	if err != nil {
+		t.Fatalf("newEncoder: %v", err)
+	}
+	if err := enc.Encode([]row{{Name: "web.1", App: "myapp"}}); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	header := strings.SplitN(buf.String(), "\n", 2)[0]
+	if want := "name,app"; header != want {
+		t.Errorf("csv header = %q, want %q", header, want)
+	}
+}
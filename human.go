@@ -0,0 +1,34 @@
+package main
+
+import "fmt"
+
+// sizeUnits is the flag.StringVar target for --units: si, iec, or
+// bytes.
+var sizeUnits string
+
+// human formats size bytes according to sizeUnits. "si" divides by
+// 1000 through the k/M/G/T table, "iec" divides by 1024 through the
+// Ki/Mi/Gi/Ti table, and "bytes" prints the raw integer.
+func human(size int64) string {
+	switch sizeUnits {
+	case "iec":
+		return humanUnits(size, 1024, []string{"B", "KiB", "MiB", "GiB", "TiB"})
+	case "bytes":
+		return fmt.Sprintf("%d", size)
+	default:
+		return humanUnits(size, 1000, []string{"B", "kB", "MB", "GB", "TB"})
+	}
+}
+
+func humanUnits(size int64, base float64, units []string) string {
+	f := float64(size)
+	i := 0
+	for f >= base && i < len(units)-1 {
+		f /= base
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%d %s", size, units[i])
+	}
+	return fmt.Sprintf("%.1f %s", f, units[i])
+}
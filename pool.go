@@ -0,0 +1,26 @@
+package main
+
+// maxFetchWorkers bounds how many apps are queried concurrently by
+// the multi-app dynos/releases listings, so pointing hk at a large
+// fleet doesn't open hundreds of connections at once.
+const maxFetchWorkers = 8
+
+// forEachApp calls fn(app) for each of apps, running up to
+// maxFetchWorkers calls concurrently, and waits for them all to
+// finish before returning.
+func forEachApp(apps []string, fn func(app string)) {
+	sem := make(chan struct{}, maxFetchWorkers)
+	done := make(chan struct{}, len(apps))
+	for _, app := range apps {
+		app := app
+		go func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			fn(app)
+			done <- struct{}{}
+		}()
+	}
+	for range apps {
+		<-done
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var relDurationRe = regexp.MustCompile(`^(\d+)([dhms])$`)
+
+// parseSince parses a --since value into an absolute cutoff time.
+// It accepts a relative duration (7d, 24h, 30m, 45s) measured back
+// from now, or an absolute RFC3339 timestamp.
+func parseSince(s string) (time.Time, error) {
+	if m := relDurationRe.FindStringSubmatch(s); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return time.Time{}, err
+		}
+		var unit time.Duration
+		switch m[2] {
+		case "d":
+			unit = 24 * time.Hour
+		case "h":
+			unit = time.Hour
+		case "m":
+			unit = time.Minute
+		case "s":
+			unit = time.Second
+		}
+		return time.Now().Add(-time.Duration(n) * unit), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
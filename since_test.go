@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSinceRelative(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"30s", 30 * time.Second},
+		{"45m", 45 * time.Minute},
+		{"24h", 24 * time.Hour},
+		{"7d", 7 * 24 * time.Hour},
+	}
+	for _, c := range cases {
+		got, err := parseSince(c.in)
+		if err != nil {
+			t.Errorf("parseSince(%q): %v", c.in, err)
+			continue
+		}
+		want := time.Now().Add(-c.want)
+		if d := want.Sub(got); d < -time.Minute || d > time.Minute {
+			t.Errorf("parseSince(%q) = %v, want ~%v", c.in, got, want)
+		}
+	}
+}
+
+func TestParseSinceAbsolute(t *testing.T) {
+	want := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	got, err := parseSince(want.Format(time.RFC3339))
+	if err != nil {
+		t.Fatalf("parseSince: %v", err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("parseSince = %v, want %v", got, want)
+	}
+}
+
+func TestParseSinceInvalid(t *testing.T) {
+	if _, err := parseSince("not-a-time"); err == nil {
+		t.Error("parseSince(\"not-a-time\") = nil error, want error")
+	}
+}
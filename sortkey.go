@@ -0,0 +1,169 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// sortKey is one comma-separated term of a --sort value, e.g. the
+// "-released" in --sort=-released,name.
+type sortKey struct {
+	name string
+	desc bool
+}
+
+// parseSortKeys splits a --sort value into its keys. A leading "-"
+// on a key reverses that key's order. An empty spec yields no keys,
+// meaning "use the listing's default order".
+func parseSortKeys(spec string) []sortKey {
+	if spec == "" {
+		return nil
+	}
+	var keys []sortKey
+	for _, part := range strings.Split(spec, ",") {
+		desc := false
+		if strings.HasPrefix(part, "-") {
+			desc = true
+			part = part[1:]
+		}
+		keys = append(keys, sortKey{part, desc})
+	}
+	return keys
+}
+
+// cmpFunc compares elements i and j of some slice, returning <0, 0,
+// or >0 as element i sorts before, equal to, or after element j.
+type cmpFunc func(i, j int) int
+
+// byCmp adapts a length, swap, and an ordered list of cmpFuncs (one
+// per sort key, tried in order until one returns non-zero) into a
+// sort.Interface.
+type byCmp struct {
+	n    int
+	swap func(i, j int)
+	cmps []cmpFunc
+}
+
+func (b byCmp) Len() int      { return b.n }
+func (b byCmp) Swap(i, j int) { b.swap(i, j) }
+func (b byCmp) Less(i, j int) bool {
+	for _, cmp := range b.cmps {
+		if c := cmp(i, j); c != 0 {
+			return c < 0
+		}
+	}
+	return false
+}
+
+func cmpString(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func cmpTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// sortByKeys sorts n elements (with the given swap func) by keys,
+// resolving each key's name to a cmpFunc via field. Unknown key
+// names are ignored.
+func sortByKeys(n int, swap func(i, j int), keys []sortKey, field func(name string) cmpFunc) {
+	var cmps []cmpFunc
+	for _, k := range keys {
+		cmp := field(k.name)
+		if cmp == nil {
+			continue
+		}
+		if k.desc {
+			inner := cmp
+			cmp = func(i, j int) int { return -inner(i, j) }
+		}
+		cmps = append(cmps, cmp)
+	}
+	if len(cmps) == 0 {
+		return
+	}
+	sort.Sort(byCmp{n, swap, cmps})
+}
+
+// appSortField resolves a --sort key name to a cmpFunc over apps,
+// for name|owner|size|released.
+func appSortField(apps []*App) func(name string) cmpFunc {
+	return func(name string) cmpFunc {
+		switch name {
+		case "name":
+			return func(i, j int) int { return cmpString(apps[i].Name, apps[j].Name) }
+		case "owner":
+			return func(i, j int) int { return cmpString(apps[i].Owner.Email, apps[j].Owner.Email) }
+		case "size":
+			return func(i, j int) int {
+				return int(appSlugSize(apps[i])) - int(appSlugSize(apps[j]))
+			}
+		case "released":
+			return func(i, j int) int { return cmpTime(appReleasedAt(apps[i]), appReleasedAt(apps[j])) }
+		}
+		return nil
+	}
+}
+
+func appSlugSize(a *App) int {
+	if a.SlugSize != nil {
+		return *a.SlugSize
+	}
+	return 0
+}
+
+func appReleasedAt(a *App) time.Time {
+	if a.ReleasedAt != nil {
+		return *a.ReleasedAt
+	}
+	return a.CreatedAt
+}
+
+// releaseSortField resolves a --sort key name to a cmpFunc over
+// releases, for name|created|user|commit.
+func releaseSortField(rels []*Release) func(name string) cmpFunc {
+	return func(name string) cmpFunc {
+		switch name {
+		case "name":
+			return func(i, j int) int { return cmpString(rels[i].Name, rels[j].Name) }
+		case "created":
+			return func(i, j int) int { return cmpTime(rels[i].CreatedAt.Time, rels[j].CreatedAt.Time) }
+		case "user":
+			return func(i, j int) int { return cmpString(rels[i].User, rels[j].User) }
+		case "commit":
+			return func(i, j int) int { return cmpString(rels[i].Commit, rels[j].Commit) }
+		}
+		return nil
+	}
+}
+
+// dynoSortField resolves a --sort key name to a cmpFunc over dynos,
+// for name|state|age|command.
+func dynoSortField(dynos []*Dyno) func(name string) cmpFunc {
+	return func(name string) cmpFunc {
+		switch name {
+		case "name":
+			return func(i, j int) int { return cmpString(dynos[i].Name, dynos[j].Name) }
+		case "state":
+			return func(i, j int) int { return cmpString(dynos[i].State, dynos[j].State) }
+		case "age":
+			return func(i, j int) int { return int(dynos[i].Age() - dynos[j].Age()) }
+		case "command":
+			return func(i, j int) int { return cmpString(dynos[i].Command, dynos[j].Command) }
+		}
+		return nil
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSortKeys(t *testing.T) {
+	cases := []struct {
+		spec string
+		want []sortKey
+	}{
+		{"", nil},
+		{"name", []sortKey{{"name", false}}},
+		{"-released,name", []sortKey{{"released", true}, {"name", false}}},
+	}
+	for _, c := range cases {
+		got := parseSortKeys(c.spec)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseSortKeys(%q) = %#v, want %#v", c.spec, got, c.want)
+		}
+	}
+}
+
+func TestSortByKeys(t *testing.T) {
+	names := []string{"b", "c", "a"}
+	field := func(name string) cmpFunc {
+		if name != "name" {
+			return nil
+		}
+		return func(i, j int) int { return cmpString(names[i], names[j]) }
+	}
+	swap := func(i, j int) { names[i], names[j] = names[j], names[i] }
+
+	sortByKeys(len(names), swap, parseSortKeys("name"), field)
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("sort ascending = %v, want %v", names, want)
+	}
+
+	sortByKeys(len(names), swap, parseSortKeys("-name"), field)
+	if want := []string{"c", "b", "a"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("sort descending = %v, want %v", names, want)
+	}
+}
+
+func TestSortByKeysUnknownKeyIgnored(t *testing.T) {
+	names := []string{"b", "a"}
+	field := func(name string) cmpFunc { return nil }
+	swap := func(i, j int) { names[i], names[j] = names[j], names[i] }
+
+	sortByKeys(len(names), swap, parseSortKeys("bogus"), field)
+	if want := []string{"b", "a"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("sort with unknown key = %v, want unchanged %v", names, want)
+	}
+}
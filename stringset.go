@@ -0,0 +1,37 @@
+package main
+
+import "strings"
+
+// stringSetFlag is a flag.Value that accepts a comma-separated list
+// of values, e.g. --state=up,starting. An empty (unset) set matches
+// everything via Includes.
+type stringSetFlag map[string]bool
+
+func (s stringSetFlag) String() string {
+	vals := make([]string, 0, len(s))
+	for v := range s {
+		vals = append(vals, v)
+	}
+	return strings.Join(vals, ",")
+}
+
+func (s *stringSetFlag) Set(v string) error {
+	if *s == nil {
+		*s = make(stringSetFlag)
+	}
+	for _, part := range strings.Split(v, ",") {
+		if part != "" {
+			(*s)[part] = true
+		}
+	}
+	return nil
+}
+
+// Includes reports whether v is in the set. An empty set includes
+// everything, so an unset flag behaves like no filter at all.
+func (s stringSetFlag) Includes(v string) bool {
+	if len(s) == 0 {
+		return true
+	}
+	return s[v]
+}
@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestStringSetFlagIncludesEmpty(t *testing.T) {
+	var s stringSetFlag
+	if !s.Includes("anything") {
+		t.Error("empty stringSetFlag should include everything")
+	}
+}
+
+func TestStringSetFlagSetAndIncludes(t *testing.T) {
+	var s stringSetFlag
+	if err := s.Set("up,starting"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	for _, v := range []string{"up", "starting"} {
+		if !s.Includes(v) {
+			t.Errorf("Includes(%q) = false, want true", v)
+		}
+	}
+	if s.Includes("crashed") {
+		t.Error("Includes(\"crashed\") = true, want false")
+	}
+}
@@ -0,0 +1,13 @@
+package main
+
+import "os"
+
+// isTerminal reports whether f is connected to a terminal, as
+// opposed to a pipe or redirected file.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}